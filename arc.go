@@ -0,0 +1,293 @@
+package lru
+
+import "container/list"
+
+// arcList identifies which of ARC's four internal lists an entry
+// currently lives in.
+type arcList int
+
+const (
+	arcT1 arcList = iota // recent, resident
+	arcT2                // frequent, resident
+	arcB1                // recent-evicted ghost (keys only)
+	arcB2                // frequent-evicted ghost (keys only)
+)
+
+type arcEntry[K comparable, V any] struct {
+	key   K
+	value V
+	which arcList
+}
+
+// ARC is a fixed size Adaptive Replacement Cache, as described in
+// "ARC: A Self-Tuning, Low Overhead Replacement Cache" (Megiddo & Modha).
+// It adapts between recency and frequency by tracking ghost entries for
+// recently evicted keys, without requiring any tuning from the caller.
+type ARC[K comparable, V any] struct {
+	size int
+	p    int // target size of t1
+
+	t1, t2, b1, b2 *list.List // *arcEntry[K, V]
+	elem           map[K]*list.Element
+
+	evicted func(K, V)
+}
+
+// NewARC creates a new ARC cache of the given size.
+func NewARC[K comparable, V any](size int) *ARC[K, V] {
+	return &ARC[K, V]{
+		size: size,
+		t1:   list.New(),
+		t2:   list.New(),
+		b1:   list.New(),
+		b2:   list.New(),
+		elem: make(map[K]*list.Element),
+	}
+}
+
+// OnEvicted optionally specifies a callback function to be
+// executed when an entry is evicted from the arc cache.
+func (arc *ARC[K, V]) OnEvicted(cb func(K, V)) {
+	arc.evicted = cb
+}
+
+func (arc *ARC[K, V]) listFor(which arcList) *list.List {
+	switch which {
+	case arcT1:
+		return arc.t1
+	case arcT2:
+		return arc.t2
+	case arcB1:
+		return arc.b1
+	default:
+		return arc.b2
+	}
+}
+
+// Get looks up a key's value from the arc cache.
+func (arc *ARC[K, V]) Get(key K) (value V, ok bool) {
+	elem := arc.elem[key]
+	if elem == nil {
+		return
+	}
+
+	e := elem.Value.(*arcEntry[K, V])
+	if e.which != arcT1 && e.which != arcT2 {
+		return
+	}
+
+	arc.listFor(e.which).Remove(elem)
+	e.which = arcT2
+	arc.elem[key] = arc.t2.PushFront(e)
+	return e.value, true
+}
+
+// Pick returns the key value (or undefined if not found)
+// without updating the "recently used"-ness of the key.
+func (arc *ARC[K, V]) Pick(key K) (value V, ok bool) {
+	elem := arc.elem[key]
+	if elem == nil {
+		return
+	}
+
+	e := elem.Value.(*arcEntry[K, V])
+	if e.which != arcT1 && e.which != arcT2 {
+		return
+	}
+	return e.value, true
+}
+
+// Set sets a value to the arc cache.
+func (arc *ARC[K, V]) Set(key K, value V) {
+	if elem := arc.elem[key]; elem != nil {
+		e := elem.Value.(*arcEntry[K, V])
+		switch e.which {
+		case arcT1, arcT2:
+			arc.listFor(e.which).Remove(elem)
+			e.which = arcT2
+			e.value = value
+			arc.elem[key] = arc.t2.PushFront(e)
+			return
+		case arcB1:
+			b1Len, b2Len := arc.b1.Len(), arc.b2.Len()
+			delta := max(b2Len/b1Len, 1)
+			arc.p = min(arc.size, arc.p+delta)
+			arc.replace(key)
+			arc.b1.Remove(elem)
+			e.which = arcT2
+			e.value = value
+			arc.elem[key] = arc.t2.PushFront(e)
+			return
+		case arcB2:
+			b1Len, b2Len := arc.b1.Len(), arc.b2.Len()
+			delta := max(b1Len/b2Len, 1)
+			arc.p = max(0, arc.p-delta)
+			arc.replace(key)
+			arc.b2.Remove(elem)
+			e.which = arcT2
+			e.value = value
+			arc.elem[key] = arc.t2.PushFront(e)
+			return
+		}
+	}
+
+	t1Len, b1Len := arc.t1.Len(), arc.b1.Len()
+	switch {
+	case t1Len+b1Len == arc.size:
+		if t1Len < arc.size {
+			arc.removeGhost(arc.b1)
+			arc.replace(key)
+		} else {
+			arc.evictResident(arc.t1)
+		}
+	case t1Len+arc.t2.Len()+b1Len+arc.b2.Len() >= arc.size:
+		if t1Len+arc.t2.Len()+b1Len+arc.b2.Len() == 2*arc.size {
+			arc.removeGhost(arc.b2)
+		}
+		arc.replace(key)
+	}
+
+	arc.elem[key] = arc.t1.PushFront(&arcEntry[K, V]{key: key, value: value, which: arcT1})
+}
+
+// replace demotes the LRU entry of t1 or t2 to a ghost list, per the
+// ARC replacement rule, favoring t1 when it has grown past its target
+// size p (or is tied with p and key is currently a frequent ghost).
+func (arc *ARC[K, V]) replace(key K) {
+	inB2 := false
+	if elem := arc.elem[key]; elem != nil {
+		if e := elem.Value.(*arcEntry[K, V]); e.which == arcB2 {
+			inB2 = true
+		}
+	}
+
+	t1Len := arc.t1.Len()
+	if t1Len > 0 && (t1Len > arc.p || (inB2 && t1Len == arc.p)) {
+		arc.demote(arc.t1, arcB1, arc.b1)
+	} else {
+		arc.demote(arc.t2, arcB2, arc.b2)
+	}
+}
+
+// demote moves the LRU resident entry of from into the MRU position of
+// to as a valueless ghost, firing OnEvicted with the value it held.
+func (arc *ARC[K, V]) demote(from *list.List, toWhich arcList, to *list.List) {
+	elem := from.Back()
+	if elem == nil {
+		return
+	}
+
+	e := elem.Value.(*arcEntry[K, V])
+	from.Remove(elem)
+
+	value := e.value
+	var zero V
+	e.value = zero
+	e.which = toWhich
+	arc.elem[e.key] = to.PushFront(e)
+
+	if arc.evicted != nil {
+		arc.evicted(e.key, value)
+	}
+}
+
+// removeGhost drops the LRU ghost entry of the given list entirely.
+func (arc *ARC[K, V]) removeGhost(ghost *list.List) {
+	elem := ghost.Back()
+	if elem == nil {
+		return
+	}
+
+	e := elem.Value.(*arcEntry[K, V])
+	ghost.Remove(elem)
+	delete(arc.elem, e.key)
+}
+
+// evictResident drops the LRU resident entry of the given list
+// entirely (no ghost is kept), firing OnEvicted.
+func (arc *ARC[K, V]) evictResident(resident *list.List) {
+	elem := resident.Back()
+	if elem == nil {
+		return
+	}
+
+	e := elem.Value.(*arcEntry[K, V])
+	resident.Remove(elem)
+	delete(arc.elem, e.key)
+
+	if arc.evicted != nil {
+		arc.evicted(e.key, e.value)
+	}
+}
+
+// Remove removes the provided key from the cache.
+func (arc *ARC[K, V]) Remove(key K) (value V, ok bool) {
+	elem := arc.elem[key]
+	if elem == nil {
+		return
+	}
+
+	e := elem.Value.(*arcEntry[K, V])
+	if e.which != arcT1 && e.which != arcT2 {
+		return
+	}
+
+	arc.listFor(e.which).Remove(elem)
+	delete(arc.elem, key)
+
+	if arc.evicted != nil {
+		arc.evicted(e.key, e.value)
+	}
+	return e.value, true
+}
+
+// Len returns the number of resident items in the arc cache.
+func (arc *ARC[K, V]) Len() int {
+	return arc.t1.Len() + arc.t2.Len()
+}
+
+// Clear purges all stored items and ghost entries from the arc cache.
+func (arc *ARC[K, V]) Clear() {
+	arc.t1 = list.New()
+	arc.t2 = list.New()
+	arc.b1 = list.New()
+	arc.b2 = list.New()
+	arc.elem = make(map[K]*list.Element)
+	arc.p = 0
+}
+
+// All returns an iterator over key-value pairs in the arc cache,
+// traversing the frequent (t2) items before the recent (t1) ones, each
+// from newest to oldest.
+func (arc *ARC[K, V]) All() func(yield func(K, V) bool) {
+	return func(yield func(K, V) bool) {
+		for _, lst := range [2]*list.List{arc.t2, arc.t1} {
+			var next *list.Element
+			for elem := lst.Front(); elem != nil; elem = next {
+				next = elem.Next()
+				e := elem.Value.(*arcEntry[K, V])
+				if !yield(e.key, e.value) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over key-value pairs in the arc cache,
+// traversing the recent (t1) items before the frequent (t2) ones, each
+// from oldest to newest.
+func (arc *ARC[K, V]) Backward() func(yield func(K, V) bool) {
+	return func(yield func(K, V) bool) {
+		for _, lst := range [2]*list.List{arc.t1, arc.t2} {
+			var prev *list.Element
+			for elem := lst.Back(); elem != nil; elem = prev {
+				prev = elem.Prev()
+				e := elem.Value.(*arcEntry[K, V])
+				if !yield(e.key, e.value) {
+					return
+				}
+			}
+		}
+	}
+}
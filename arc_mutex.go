@@ -0,0 +1,101 @@
+package lru
+
+import "sync"
+
+// MutexARC is a thread-safe ARC cache.
+type MutexARC[K comparable, V any] struct {
+	mut sync.RWMutex
+	arc *ARC[K, V]
+}
+
+// NewARCWithMutex creates a new thread-safe ARC cache of the given size.
+func NewARCWithMutex[K comparable, V any](size int) *MutexARC[K, V] {
+	return &MutexARC[K, V]{
+		arc: NewARC[K, V](size),
+	}
+}
+
+// OnEvicted optionally specifies a callback function to be
+// executed when an entry is evicted from the arc cache.
+func (m *MutexARC[K, V]) OnEvicted(cb func(K, V)) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	m.arc.OnEvicted(func(k K, v V) {
+		m.mut.Unlock()
+		defer m.mut.Lock()
+		cb(k, v)
+	})
+}
+
+// Set sets a value to the arc cache.
+func (m *MutexARC[K, V]) Set(key K, value V) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	m.arc.Set(key, value)
+}
+
+// Get looks up a key's value from the arc cache.
+func (m *MutexARC[K, V]) Get(key K) (value V, ok bool) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	return m.arc.Get(key)
+}
+
+// Pick returns the key value (or undefined if not found)
+// without updating the "recently used"-ness of the key.
+func (m *MutexARC[K, V]) Pick(key K) (value V, ok bool) {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+	return m.arc.Pick(key)
+}
+
+// Remove removes the provided key from the arc cache.
+func (m *MutexARC[K, V]) Remove(key K) (value V, ok bool) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	return m.arc.Remove(key)
+}
+
+// Len returns the number of resident items in the arc cache.
+func (m *MutexARC[K, V]) Len() int {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+	return m.arc.Len()
+}
+
+// Clear purges all stored items and ghost entries from the arc cache.
+func (m *MutexARC[K, V]) Clear() {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	m.arc.Clear()
+}
+
+// All returns an iterator over key-value pairs in the arc cache,
+// traversing the frequent items before the recent ones, each from
+// newest to oldest.
+func (m *MutexARC[K, V]) All() func(yield func(K, V) bool) {
+	return func(yield func(K, V) bool) {
+		m.mut.Lock()
+		defer m.mut.Unlock()
+		m.arc.All()(func(key K, value V) bool {
+			m.mut.Unlock()
+			defer m.mut.Lock()
+			return yield(key, value)
+		})
+	}
+}
+
+// Backward returns an iterator over key-value pairs in the arc cache,
+// traversing the recent items before the frequent ones, each from
+// oldest to newest.
+func (m *MutexARC[K, V]) Backward() func(yield func(K, V) bool) {
+	return func(yield func(K, V) bool) {
+		m.mut.Lock()
+		defer m.mut.Unlock()
+		m.arc.Backward()(func(key K, value V) bool {
+			m.mut.Unlock()
+			defer m.mut.Lock()
+			return yield(key, value)
+		})
+	}
+}
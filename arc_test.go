@@ -0,0 +1,133 @@
+package lru
+
+import "testing"
+
+func TestARCSetGet(t *testing.T) {
+	arc := NewARC[string, int](3)
+	arc.Set("a", 1)
+	arc.Set("b", 2)
+
+	if a, ok := arc.Get("a"); !ok || a != 1 {
+		t.Fatalf("get a: %v %v", a, ok)
+	}
+	if c, ok := arc.Get("c"); ok || c != 0 {
+		t.Fatalf("get c: %v %v", c, ok)
+	}
+}
+
+func TestARCPick(t *testing.T) {
+	arc := NewARC[string, int](3)
+	arc.Set("a", 1)
+
+	if a, ok := arc.Pick("a"); !ok || a != 1 {
+		t.Fatalf("pick a: %v %v", a, ok)
+	}
+}
+
+func TestARCRemove(t *testing.T) {
+	arc := NewARC[string, int](3)
+	arc.Set("a", 1)
+	arc.Set("b", 2)
+
+	if a, ok := arc.Remove("a"); !ok || a != 1 {
+		t.Fatalf("remove a: %v %v", a, ok)
+	}
+	if arc.Len() != 1 {
+		t.Fatalf("len: %v", arc.Len())
+	}
+	if a, ok := arc.Get("a"); ok || a != 0 {
+		t.Fatalf("get a after remove: %v %v", a, ok)
+	}
+}
+
+func TestARCClear(t *testing.T) {
+	arc := NewARC[string, int](3)
+	arc.Set("a", 1)
+	arc.Set("b", 2)
+
+	arc.Clear()
+
+	if arc.Len() != 0 {
+		t.Fatalf("len after clear: %v", arc.Len())
+	}
+	if a, ok := arc.Get("a"); ok || a != 0 {
+		t.Fatalf("get a after clear: %v %v", a, ok)
+	}
+}
+
+func TestARCFrequentSurvivesRecentScan(t *testing.T) {
+	arc := NewARC[int, int](4)
+
+	// Key 1 becomes frequent by being hit again before the scan.
+	arc.Set(1, 1)
+	arc.Get(1)
+
+	// A long one-time scan of distinct keys should mostly pressure T1,
+	// not evict the frequent key out of the cache entirely.
+	for i := 2; i <= 20; i++ {
+		arc.Set(i, i)
+	}
+
+	if _, ok := arc.Get(1); !ok {
+		t.Fatalf("frequent key 1 was evicted during scan")
+	}
+}
+
+func TestARCGhostPromotion(t *testing.T) {
+	arc := NewARC[string, int](2)
+	arc.Set("a", 1)
+	arc.Get("a") // promote "a" to t2
+	arc.Set("b", 2)
+	arc.Set("c", 3) // demotes "b", the LRU of t1, into b1
+
+	if arc.Len() != 2 {
+		t.Fatalf("len: %v", arc.Len())
+	}
+	if _, ok := arc.Pick("b"); ok {
+		t.Fatalf("b should be a ghost entry")
+	}
+
+	// Re-inserting a ghost key should adapt p and resurrect it as frequent.
+	arc.Set("b", 22)
+	if arc.p != 1 {
+		t.Fatalf("p after ghost hit: %v", arc.p)
+	}
+	if e := arc.elem["b"].Value.(*arcEntry[string, int]); e.which != arcT2 {
+		t.Fatalf("b after ghost hit is in list %v, want t2", e.which)
+	}
+	if b, ok := arc.Get("b"); !ok || b != 22 {
+		t.Fatalf("get b after ghost hit: %v %v", b, ok)
+	}
+}
+
+func TestARCOnEvicted(t *testing.T) {
+	arc := NewARC[string, int](1)
+	var evictedKey string
+	arc.OnEvicted(func(k string, _ int) {
+		evictedKey = k
+	})
+
+	arc.Set("a", 1)
+	arc.Set("b", 2)
+
+	if evictedKey != "a" {
+		t.Fatalf("evicted: %q", evictedKey)
+	}
+}
+
+func TestARCAll(t *testing.T) {
+	arc := NewARC[string, int](3)
+	arc.Set("a", 1)
+	arc.Set("b", 2)
+	arc.Get("a") // promote "a" to frequent
+
+	var keys []string
+	arc.All()(func(k string, _ int) bool {
+		keys = append(keys, k)
+		return true
+	})
+
+	if len(keys) != 2 || keys[0] != "a" || keys[1] != "b" {
+		t.Fatalf("all order: %v", keys)
+	}
+}
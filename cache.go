@@ -0,0 +1,27 @@
+package lru
+
+// Cache is the common read/write surface shared by LRU, MutexLRU, and
+// the other cache variants in this package (ARC, TwoQueue, ShardedLRU,
+// and their mutex-wrapped counterparts). It lets callers such as
+// Loading work with any of them interchangeably.
+type Cache[K comparable, V any] interface {
+	Set(key K, value V)
+	Get(key K) (value V, ok bool)
+	Pick(key K) (value V, ok bool)
+	Remove(key K) (value V, ok bool)
+	Len() int
+	Clear()
+	OnEvicted(cb func(K, V))
+	All() func(yield func(K, V) bool)
+	Backward() func(yield func(K, V) bool)
+}
+
+var (
+	_ Cache[string, int] = (*LRU[string, int])(nil)
+	_ Cache[string, int] = (*MutexLRU[string, int])(nil)
+	_ Cache[string, int] = (*ARC[string, int])(nil)
+	_ Cache[string, int] = (*MutexARC[string, int])(nil)
+	_ Cache[string, int] = (*TwoQueue[string, int])(nil)
+	_ Cache[string, int] = (*MutexTwoQueue[string, int])(nil)
+	_ Cache[string, int] = (*ShardedLRU[string, int])(nil)
+)
@@ -0,0 +1,160 @@
+package lru
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// call tracks a single in-flight load, shared by every caller currently
+// waiting on the same key. Its context is independent of any one
+// waiter's context: it's only cancelled once the last waiter leaves,
+// so one caller giving up doesn't abort the load for everyone else.
+type call[V any] struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	waiters int
+
+	done  chan struct{}
+	value V
+	err   error
+}
+
+type negativeEntry[V any] struct {
+	err       error
+	expiresAt time.Time
+}
+
+// Loading wraps a Cache with a read-through loader: a miss triggers
+// loader, concurrent misses for the same key single-flight onto one
+// loader call, and a successful result is written back to the cache.
+//
+// Loading serializes its own access to the wrapped Cache (via cacheMu),
+// so c is not required to be thread-safe itself; a plain LRU or ARC
+// works just as well as a MutexLRU here.
+type Loading[K comparable, V any] struct {
+	cache   Cache[K, V]
+	cacheMu sync.Mutex
+	loader  func(ctx context.Context, key K) (V, error)
+
+	// LoadTTL, if set, is consulted after a failed load to decide how
+	// long that error should be remembered so concurrent and
+	// subsequent callers get it back immediately instead of retrying
+	// the loader. A zero or negative duration means don't cache it.
+	LoadTTL func(key K, err error) time.Duration
+
+	mut   sync.Mutex
+	calls map[K]*call[V]
+	neg   map[K]negativeEntry[V]
+}
+
+// NewLoading creates a Loading cache on top of c, calling loader on a
+// miss.
+func NewLoading[K comparable, V any](c Cache[K, V], loader func(ctx context.Context, key K) (V, error)) *Loading[K, V] {
+	return &Loading[K, V]{
+		cache:  c,
+		loader: loader,
+		calls:  make(map[K]*call[V]),
+		neg:    make(map[K]negativeEntry[V]),
+	}
+}
+
+// GetOrLoad returns the cached value for key, loading it via loader on
+// a miss. Concurrent calls for the same key share one loader call: only
+// the first triggers it, the rest block on its result. If ctx is
+// cancelled while waiting, GetOrLoad returns ctx.Err() without
+// cancelling the load for any other still-waiting caller.
+func (l *Loading[K, V]) GetOrLoad(ctx context.Context, key K) (value V, err error) {
+	l.cacheMu.Lock()
+	value, ok := l.cache.Get(key)
+	l.cacheMu.Unlock()
+	if ok {
+		return value, nil
+	}
+
+	l.mut.Lock()
+	if neg, ok := l.neg[key]; ok {
+		if neg.expiresAt.After(time.Now()) {
+			l.mut.Unlock()
+			return value, neg.err
+		}
+		delete(l.neg, key)
+	}
+
+	if c, ok := l.calls[key]; ok {
+		c.waiters++
+		l.mut.Unlock()
+		return l.wait(ctx, c)
+	}
+
+	cctx, cancel := context.WithCancel(context.Background())
+	c := &call[V]{
+		ctx:     cctx,
+		cancel:  cancel,
+		waiters: 1,
+		done:    make(chan struct{}),
+	}
+	l.calls[key] = c
+	l.mut.Unlock()
+
+	go l.load(key, c)
+
+	return l.wait(ctx, c)
+}
+
+func (l *Loading[K, V]) wait(ctx context.Context, c *call[V]) (value V, err error) {
+	defer func() {
+		l.mut.Lock()
+		c.waiters--
+		if c.waiters == 0 {
+			c.cancel()
+		}
+		l.mut.Unlock()
+	}()
+
+	select {
+	case <-c.done:
+		return c.value, c.err
+	case <-ctx.Done():
+		return value, ctx.Err()
+	}
+}
+
+func (l *Loading[K, V]) load(key K, c *call[V]) {
+	c.value, c.err = l.loader(c.ctx, key)
+
+	if c.err == nil {
+		l.cacheMu.Lock()
+		l.cache.Set(key, c.value)
+		l.cacheMu.Unlock()
+	}
+
+	l.mut.Lock()
+	// Only remove our own entry: Forget may have already replaced it
+	// with a fresh in-flight call for the same key.
+	if l.calls[key] == c {
+		delete(l.calls, key)
+	}
+	if c.err != nil && l.LoadTTL != nil {
+		if ttl := l.LoadTTL(key, c.err); ttl > 0 {
+			l.neg[key] = negativeEntry[V]{err: c.err, expiresAt: time.Now().Add(ttl)}
+		}
+	}
+	l.mut.Unlock()
+
+	close(c.done)
+}
+
+// Forget drops any in-flight load and cached negative result for key,
+// so the next GetOrLoad starts a fresh loader call.
+func (l *Loading[K, V]) Forget(key K) {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+	if c, ok := l.calls[key]; ok {
+		c.cancel()
+		if l.calls[key] == c {
+			delete(l.calls, key)
+		}
+	}
+	delete(l.neg, key)
+}
@@ -0,0 +1,259 @@
+package lru
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLoadingGetOrLoadCachesOnSuccess(t *testing.T) {
+	var calls int32
+	l := NewLoading[string, int](NewWithMutex[string, int](8), func(ctx context.Context, key string) (int, error) {
+		atomic.AddInt32(&calls, 1)
+		return len(key), nil
+	})
+
+	v, err := l.GetOrLoad(context.Background(), "hello")
+	if err != nil || v != 5 {
+		t.Fatalf("first load: %v %v", v, err)
+	}
+
+	v, err = l.GetOrLoad(context.Background(), "hello")
+	if err != nil || v != 5 {
+		t.Fatalf("second load: %v %v", v, err)
+	}
+	if calls != 1 {
+		t.Fatalf("loader calls: %v", calls)
+	}
+}
+
+func TestLoadingWithPlainCacheIsConcurrencySafe(t *testing.T) {
+	// Loading must serialize its own access to the wrapped Cache: a
+	// plain, non-thread-safe LRU is the normal way this type gets used.
+	l := NewLoading[int, int](New[int, int](64), func(ctx context.Context, key int) (int, error) {
+		return key, nil
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 32; i++ {
+		for j := 0; j < 4; j++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				if _, err := l.GetOrLoad(context.Background(), i); err != nil {
+					t.Errorf("load %d: %v", i, err)
+				}
+			}(i)
+		}
+	}
+	wg.Wait()
+}
+
+func TestLoadingSingleFlight(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	l := NewLoading[string, int](NewWithMutex[string, int](8), func(ctx context.Context, key string) (int, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return 42, nil
+	})
+
+	var wg sync.WaitGroup
+	results := make([]int, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v, err := l.GetOrLoad(context.Background(), "k")
+			if err != nil {
+				t.Errorf("load %d: %v", i, err)
+			}
+			results[i] = v
+		}(i)
+	}
+
+	close(release)
+	wg.Wait()
+
+	if calls != 1 {
+		t.Fatalf("loader calls: %v", calls)
+	}
+	for i, v := range results {
+		if v != 42 {
+			t.Fatalf("result %d: %v", i, v)
+		}
+	}
+}
+
+func TestLoadingContextCancelDoesNotAbortOtherWaiters(t *testing.T) {
+	release := make(chan struct{})
+	l := NewLoading[string, int](NewWithMutex[string, int](8), func(ctx context.Context, key string) (int, error) {
+		<-release
+		return 1, nil
+	})
+
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	done1 := make(chan error, 1)
+	go func() {
+		_, err := l.GetOrLoad(ctx1, "k")
+		done1 <- err
+	}()
+
+	done2 := make(chan error, 1)
+	go func() {
+		_, err := l.GetOrLoad(context.Background(), "k")
+		done2 <- err
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	cancel1()
+
+	if err := <-done1; err != context.Canceled {
+		t.Fatalf("waiter 1 error: %v", err)
+	}
+
+	close(release)
+	if err := <-done2; err != nil {
+		t.Fatalf("waiter 2 error: %v", err)
+	}
+}
+
+func TestLoadingNegativeCaching(t *testing.T) {
+	var calls int32
+	loadErr := errors.New("boom")
+	l := NewLoading[string, int](NewWithMutex[string, int](8), func(ctx context.Context, key string) (int, error) {
+		atomic.AddInt32(&calls, 1)
+		return 0, loadErr
+	})
+	l.LoadTTL = func(key string, err error) time.Duration {
+		return 50 * time.Millisecond
+	}
+
+	_, err := l.GetOrLoad(context.Background(), "k")
+	if err != loadErr {
+		t.Fatalf("first error: %v", err)
+	}
+
+	_, err = l.GetOrLoad(context.Background(), "k")
+	if err != loadErr {
+		t.Fatalf("second error: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("loader calls: %v", calls)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	_, err = l.GetOrLoad(context.Background(), "k")
+	if err != loadErr {
+		t.Fatalf("third error: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("loader calls after ttl expiry: %v", calls)
+	}
+}
+
+func TestLoadingForgetDoesNotOrphanANewerCall(t *testing.T) {
+	var calls int32
+	invoked := make(chan int32, 8)
+	release1 := make(chan struct{})
+	release2 := make(chan struct{})
+	errBoom := errors.New("boom")
+
+	l := NewLoading[string, int](NewWithMutex[string, int](8), func(ctx context.Context, key string) (int, error) {
+		n := atomic.AddInt32(&calls, 1)
+		invoked <- n
+		if n == 1 {
+			// Ignores ctx cancellation, like a real network call might.
+			<-release1
+			return 0, errBoom
+		}
+		<-release2
+		return 2, nil
+	})
+
+	doneA := make(chan error, 1)
+	go func() {
+		_, err := l.GetOrLoad(context.Background(), "k")
+		doneA <- err
+	}()
+	if n := <-invoked; n != 1 {
+		t.Fatalf("expected first invocation, got %d", n)
+	}
+
+	// Forget the in-flight call while its loader is still blocked.
+	l.Forget("k")
+
+	doneB := make(chan error, 1)
+	go func() {
+		_, err := l.GetOrLoad(context.Background(), "k")
+		doneB <- err
+	}()
+	if n := <-invoked; n != 2 {
+		t.Fatalf("expected second invocation, got %d", n)
+	}
+
+	// Let the forgotten first load finish late; its cleanup must not
+	// evict the second, still in-flight call from the calls map.
+	close(release1)
+	if err := <-doneA; err != errBoom {
+		t.Fatalf("waiter A error: %v", err)
+	}
+
+	l.mut.Lock()
+	_, stillTracked := l.calls["k"]
+	l.mut.Unlock()
+	if !stillTracked {
+		t.Fatalf("the forgotten first call's cleanup evicted the second, still in-flight call")
+	}
+
+	// A third caller arriving now should join the second call, not
+	// spawn a third loader invocation.
+	doneC := make(chan error, 1)
+	go func() {
+		_, err := l.GetOrLoad(context.Background(), "k")
+		doneC <- err
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	close(release2)
+	if err := <-doneB; err != nil {
+		t.Fatalf("waiter B error: %v", err)
+	}
+	if err := <-doneC; err != nil {
+		t.Fatalf("waiter C error: %v", err)
+	}
+
+	if calls != 2 {
+		t.Fatalf("loader calls: %v, want 2", calls)
+	}
+}
+
+func TestLoadingForget(t *testing.T) {
+	release := make(chan struct{})
+	var calls int32
+	l := NewLoading[string, int](NewWithMutex[string, int](8), func(ctx context.Context, key string) (int, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return 1, nil
+	})
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := l.GetOrLoad(context.Background(), "k")
+		done <- err
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	l.Forget("k")
+	close(release)
+
+	if err := <-done; err != nil {
+		t.Fatalf("load after forget: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("loader calls: %v", calls)
+	}
+}
@@ -1,10 +1,19 @@
 package lru
 
-import "container/list"
+import (
+	"container/list"
+	"time"
+)
 
 type item[K comparable, V any] struct {
-	key   K
-	value V
+	key        K
+	value      V
+	generation int64
+	expiresAt  time.Time // zero value means no expiration
+}
+
+func (it *item[K, V]) expired(now time.Time) bool {
+	return !it.expiresAt.IsZero() && !it.expiresAt.After(now)
 }
 
 // LRU is a fixed size LRU cache.
@@ -13,6 +22,18 @@ type LRU[K comparable, V any] struct {
 	items   *list.List          // *item[K, V]
 	size    int
 	evicted func(K, V)
+
+	defaultTTL time.Duration
+
+	// currentGeneration is bumped by Clear so that every item stamped
+	// with an older generation is treated as absent without having to
+	// walk the list and unlink it right away.
+	currentGeneration int64
+	// length is the logical number of live items under currentGeneration.
+	// It's tracked separately from items.Len() because stale-generation
+	// elements may still be lingering in the list, waiting to be unlinked
+	// lazily the next time they're touched.
+	length int
 }
 
 // New creates a new LRU cache.
@@ -26,81 +47,216 @@ func New[K comparable, V any](size int) *LRU[K, V] {
 	}
 }
 
+// NewWithDefaultTTL creates a new LRU cache whose entries expire after
+// defaultTTL unless overridden with SetWithTTL.
+// If size is zero, the LRU has no limit
+// and it's assumed that eviction is done by the caller.
+func NewWithDefaultTTL[K comparable, V any](size int, defaultTTL time.Duration) *LRU[K, V] {
+	lru := New[K, V](size)
+	lru.defaultTTL = defaultTTL
+	return lru
+}
+
 // OnEvicted optionally specifies a callback function to be
 // executed when an entry is purged from the lru cache.
 func (lru *LRU[K, V]) OnEvicted(cb func(K, V)) {
 	lru.evicted = cb
 }
 
-// Set sets a value to the lru cache.
+// Set sets a value to the lru cache, expiring it after the cache's
+// default TTL, if any.
 func (lru *LRU[K, V]) Set(key K, value V) {
+	lru.SetWithTTL(key, value, lru.defaultTTL)
+}
+
+// SetWithTTL sets a value to the lru cache with a per-entry expiration.
+// A zero or negative ttl means the entry never expires.
+func (lru *LRU[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+
 	elem := lru.elem[key]
 	if elem != nil {
-		lru.items.MoveToFront(elem)
-		elem.Value.(*item[K, V]).value = value
-	} else {
-		lru.elem[key] = lru.items.PushFront(&item[K, V]{key: key, value: value})
-		if lru.size > 0 && lru.items.Len() > lru.size {
-			lru.RemoveOldest()
+		it := elem.Value.(*item[K, V])
+		if it.generation == lru.currentGeneration && !it.expired(time.Now()) {
+			lru.items.MoveToFront(elem)
+			it.value = value
+			it.expiresAt = expiresAt
+			return
+		}
+
+		// The existing entry is stale (cleared or expired) and is
+		// already logically gone; drop it before inserting the new one.
+		if it.generation == lru.currentGeneration {
+			lru.length--
 		}
+		delete(lru.elem, key)
+		lru.items.Remove(elem)
+	}
+
+	lru.elem[key] = lru.items.PushFront(&item[K, V]{
+		key:        key,
+		value:      value,
+		generation: lru.currentGeneration,
+		expiresAt:  expiresAt,
+	})
+	lru.length++
+	if lru.size > 0 && lru.length > lru.size {
+		lru.RemoveOldest()
 	}
 }
 
+// lookup returns the live item stored for key, lazily unlinking it if
+// it belongs to an older generation or has expired. An expired entry
+// fires OnEvicted; a merely stale-generation one (from a prior Clear)
+// does not, since Clear already accounts for it as gone.
+func (lru *LRU[K, V]) lookup(key K) (elem *list.Element, it *item[K, V], ok bool) {
+	elem = lru.elem[key]
+	if elem == nil {
+		return nil, nil, false
+	}
+
+	it = elem.Value.(*item[K, V])
+	if it.generation != lru.currentGeneration {
+		delete(lru.elem, key)
+		lru.items.Remove(elem)
+		return nil, nil, false
+	}
+	if it.expired(time.Now()) {
+		delete(lru.elem, key)
+		lru.items.Remove(elem)
+		lru.length--
+		if lru.evicted != nil {
+			lru.evicted(it.key, it.value)
+		}
+		return nil, nil, false
+	}
+	return elem, it, true
+}
+
 // Get looks up a key's value from the lru cache.
 func (lru *LRU[K, V]) Get(key K) (value V, ok bool) {
-	elem := lru.elem[key]
-	if elem != nil {
-		lru.items.MoveToFront(elem)
-		return elem.Value.(*item[K, V]).value, true
+	elem, it, found := lru.lookup(key)
+	if !found {
+		return
 	}
-	return
+	lru.items.MoveToFront(elem)
+	return it.value, true
 }
 
 // Peek returns the key value (or undefined if not found)
 // without updating the "recently used"-ness of the key.
+//
+// Unlike Get, Pick never mutates the cache: a stale-generation or
+// expired entry is just reported as a miss and left for Get,
+// RemoveOldest, or Purge to unlink later. This keeps Pick safe to call
+// under a read lock even though the cache now lazily evicts on touch.
 func (lru *LRU[K, V]) Pick(key K) (value V, ok bool) {
 	elem := lru.elem[key]
-	if elem != nil {
-		return elem.Value.(*item[K, V]).value, true
+	if elem == nil {
+		return
 	}
-	return
+
+	it := elem.Value.(*item[K, V])
+	if it.generation != lru.currentGeneration || it.expired(time.Now()) {
+		return
+	}
+	return it.value, true
 }
 
 // Remove removes the provided key from the cache.
 func (lru *LRU[K, V]) Remove(key K) (value V, ok bool) {
-	elem := lru.elem[key]
-	if elem != nil {
-		item := elem.Value.(*item[K, V])
-		elem.Value = nil
+	elem, it, found := lru.lookup(key)
+	if !found {
+		return
+	}
+	elem.Value = nil
 
-		delete(lru.elem, item.key)
-		lru.items.Remove(elem)
+	delete(lru.elem, it.key)
+	lru.items.Remove(elem)
+	lru.length--
 
-		if lru.evicted != nil {
-			lru.evicted(item.key, item.value)
-		}
-		return item.value, true
+	if lru.evicted != nil {
+		lru.evicted(it.key, it.value)
 	}
-	return
+	return it.value, true
 }
 
-// RemoveOldest removes the oldest item from the cache.
-func (lru *LRU[K, V]) RemoveOldest() (key K, value V, ok bool) {
-	elem := lru.items.Back()
-	if elem == nil {
+// removeQuiet removes key the same way Remove does, but without firing
+// OnEvicted. It's used internally by callers (such as TwoQueue) that
+// relocate or discard an entry themselves and need to tell that apart
+// from a real capacity-driven eviction.
+func (lru *LRU[K, V]) removeQuiet(key K) (value V, ok bool) {
+	elem, it, found := lru.lookup(key)
+	if !found {
 		return
 	}
-
-	item := elem.Value.(*item[K, V])
 	elem.Value = nil
 
-	delete(lru.elem, item.key)
+	delete(lru.elem, it.key)
 	lru.items.Remove(elem)
+	lru.length--
 
-	if lru.evicted != nil {
-		lru.evicted(item.key, item.value)
+	return it.value, true
+}
+
+// RemoveOldest removes the oldest item from the cache.
+func (lru *LRU[K, V]) RemoveOldest() (key K, value V, ok bool) {
+	for {
+		elem := lru.items.Back()
+		if elem == nil {
+			return
+		}
+
+		it := elem.Value.(*item[K, V])
+		if it.generation != lru.currentGeneration {
+			delete(lru.elem, it.key)
+			lru.items.Remove(elem)
+			continue
+		}
+
+		elem.Value = nil
+		delete(lru.elem, it.key)
+		lru.items.Remove(elem)
+		lru.length--
+
+		if lru.evicted != nil {
+			lru.evicted(it.key, it.value)
+		}
+		return it.key, it.value, true
 	}
-	return item.key, item.value, true
+}
+
+// Purge drops every currently-expired entry from the lru cache,
+// firing OnEvicted for each one removed.
+func (lru *LRU[K, V]) Purge() (purged int) {
+	now := time.Now()
+	var next *list.Element
+	for elem := lru.items.Front(); elem != nil; elem = next {
+		next = elem.Next()
+
+		it := elem.Value.(*item[K, V])
+		if it.generation != lru.currentGeneration {
+			delete(lru.elem, it.key)
+			lru.items.Remove(elem)
+			continue
+		}
+		if !it.expired(now) {
+			continue
+		}
+
+		delete(lru.elem, it.key)
+		lru.items.Remove(elem)
+		lru.length--
+		purged++
+
+		if lru.evicted != nil {
+			lru.evicted(it.key, it.value)
+		}
+	}
+	return
 }
 
 // Resize changes the lru cache size.
@@ -115,14 +271,16 @@ func (lru *LRU[K, V]) Resize(size int) (evicted int) {
 
 // Len returns the number of items in the lru cache.
 func (lru *LRU[K, V]) Len() int {
-	return lru.items.Len()
+	return lru.length
 }
 
-// Clear purges all stored items from the lru cache.
+// Clear purges all stored items from the lru cache in O(1) by bumping
+// the current generation instead of walking the list: every item
+// stamped with an older generation is treated as absent and gets
+// unlinked lazily the next time it's touched.
 func (lru *LRU[K, V]) Clear() {
-	for lru.Len() > 0 {
-		lru.RemoveOldest()
-	}
+	lru.currentGeneration++
+	lru.length = 0
 }
 
 // Backward returns an iterator over key-value pairs in the lru cache,
@@ -132,8 +290,11 @@ func (lru *LRU[K, V]) All() func(yield func(K, V) bool) {
 		var next *list.Element
 		for elem := lru.items.Front(); elem != nil; elem = next {
 			next = elem.Next()
-			item := elem.Value.(*item[K, V])
-			if !yield(item.key, item.value) {
+			it := elem.Value.(*item[K, V])
+			if it.generation != lru.currentGeneration || it.expired(time.Now()) {
+				continue
+			}
+			if !yield(it.key, it.value) {
 				return
 			}
 		}
@@ -147,8 +308,11 @@ func (lru *LRU[K, V]) Backward() func(yield func(K, V) bool) {
 		var prev *list.Element
 		for elem := lru.items.Back(); elem != nil; elem = prev {
 			prev = elem.Prev()
-			item := elem.Value.(*item[K, V])
-			if !yield(item.key, item.value) {
+			it := elem.Value.(*item[K, V])
+			if it.generation != lru.currentGeneration || it.expired(time.Now()) {
+				continue
+			}
+			if !yield(it.key, it.value) {
 				return
 			}
 		}
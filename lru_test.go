@@ -1,7 +1,9 @@
 package lru
 
 import (
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestOnEvicted(t *testing.T) {
@@ -104,6 +106,128 @@ func TestClear(t *testing.T) {
 	}
 }
 
+func TestSetWithTTL(t *testing.T) {
+	lru := New[string, int](3)
+	lru.SetWithTTL("a", 1, 10*time.Millisecond)
+
+	if a, ok := lru.Get("a"); !ok || a != 1 {
+		t.Fatalf("get a before expiry: %v %v", a, ok)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if a, ok := lru.Get("a"); ok || a != 0 {
+		t.Fatalf("get a after expiry: %v %v", a, ok)
+	}
+	if lru.Len() != 0 {
+		t.Fatalf("len after expiry: %v", lru.Len())
+	}
+}
+
+func TestNewWithDefaultTTL(t *testing.T) {
+	lru := NewWithDefaultTTL[string, int](3, 10*time.Millisecond)
+	lru.Set("a", 1)
+
+	time.Sleep(20 * time.Millisecond)
+
+	if a, ok := lru.Get("a"); ok || a != 0 {
+		t.Fatalf("get a after expiry: %v %v", a, ok)
+	}
+}
+
+func TestPurge(t *testing.T) {
+	lru := New[string, int](3)
+	var evicted []string
+	lru.OnEvicted(func(k string, _ int) {
+		evicted = append(evicted, k)
+	})
+
+	lru.SetWithTTL("a", 1, 10*time.Millisecond)
+	lru.Set("b", 2)
+
+	time.Sleep(20 * time.Millisecond)
+
+	if n := lru.Purge(); n != 1 {
+		t.Fatalf("purge count: %v", n)
+	}
+	if len(evicted) != 1 || evicted[0] != "a" {
+		t.Fatalf("evicted: %v", evicted)
+	}
+	if lru.Len() != 1 {
+		t.Fatalf("len after purge: %v", lru.Len())
+	}
+}
+
+func TestClearIsCheapAndLazy(t *testing.T) {
+	lru := New[string, int](3)
+	lru.Set("a", 1)
+	lru.Set("b", 2)
+
+	lru.Clear()
+
+	if lru.Len() != 0 {
+		t.Fatalf("len after clear: %v", lru.Len())
+	}
+	if a, ok := lru.Get("a"); ok || a != 0 {
+		t.Fatalf("get a after clear: %v %v", a, ok)
+	}
+
+	lru.Set("a", 99)
+	if a, ok := lru.Get("a"); !ok || a != 99 {
+		t.Fatalf("get a after reinsert: %v %v", a, ok)
+	}
+	if lru.Len() != 1 {
+		t.Fatalf("len after reinsert: %v", lru.Len())
+	}
+}
+
+func TestPickDoesNotMutateOnStaleOrExpired(t *testing.T) {
+	lru := New[string, int](3)
+	lru.SetWithTTL("a", 1, 10*time.Millisecond)
+	lru.Set("b", 2)
+	lru.Clear()
+	lru.SetWithTTL("c", 3, 10*time.Millisecond)
+
+	// Neither a stale-generation nor (once it expires) a TTL-expired
+	// entry should be unlinked by Pick; only a later mutating call may
+	// do that.
+	if _, ok := lru.Pick("b"); ok {
+		t.Fatalf("pick b after clear: found")
+	}
+	if lru.items.Len() == 0 {
+		t.Fatalf("pick unexpectedly unlinked stale elements")
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if _, ok := lru.Pick("a"); ok {
+		t.Fatalf("pick a after expiry: found")
+	}
+	if _, ok := lru.Pick("c"); ok {
+		t.Fatalf("pick c after expiry: found")
+	}
+	if _, linked := lru.elem["c"]; !linked {
+		t.Fatalf("pick unexpectedly unlinked the expired element")
+	}
+}
+
+func TestConcurrentPickAfterClear(t *testing.T) {
+	lru := NewWithMutex[string, int](8)
+	for i := 0; i < 8; i++ {
+		lru.Set(string(rune('a'+i)), i)
+	}
+	lru.Clear()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			lru.Pick(string(rune('a' + i)))
+		}(i)
+	}
+	wg.Wait()
+}
+
 func TestAll(t *testing.T) {
 	lru := New[int, int](10)
 	for i := 1; i <= 10; i++ {
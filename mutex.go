@@ -2,6 +2,7 @@ package lru
 
 import (
 	"sync"
+	"time"
 )
 
 // MutexLRU is a thread-safe fixed size LRU cache.
@@ -19,6 +20,16 @@ func NewWithMutex[K comparable, V any](size int) *MutexLRU[K, V] {
 	}
 }
 
+// NewWithMutexAndDefaultTTL creates a new thread-safe LRU cache whose
+// entries expire after defaultTTL unless overridden with SetWithTTL.
+// If size is zero, the LRU has no limit
+// and it's assumed that eviction is done by the caller.
+func NewWithMutexAndDefaultTTL[K comparable, V any](size int, defaultTTL time.Duration) *MutexLRU[K, V] {
+	return &MutexLRU[K, V]{
+		lru: NewWithDefaultTTL[K, V](size, defaultTTL),
+	}
+}
+
 // OnEvicted optionally specifies a callback function to be
 // executed when an entry is purged from the lru cache.
 func (m *MutexLRU[K, V]) OnEvicted(cb func(K, V)) {
@@ -38,6 +49,14 @@ func (m *MutexLRU[K, V]) Set(key K, value V) {
 	m.lru.Set(key, value)
 }
 
+// SetWithTTL sets a value to the lru cache with a per-entry expiration.
+// A zero or negative ttl means the entry never expires.
+func (m *MutexLRU[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	m.lru.SetWithTTL(key, value, ttl)
+}
+
 // Get looks up a key's value from the lru cache.
 func (m *MutexLRU[K, V]) Get(key K) (value V, ok bool) {
 	m.mut.Lock()
@@ -67,6 +86,14 @@ func (m *MutexLRU[K, V]) RemoveOldest() (key K, value V, ok bool) {
 	return m.lru.RemoveOldest()
 }
 
+// Purge drops every currently-expired entry from the lru cache,
+// firing OnEvicted for each one removed.
+func (m *MutexLRU[K, V]) Purge() (purged int) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	return m.lru.Purge()
+}
+
 // Resize changes the lru cache size.
 func (m *MutexLRU[K, V]) Resize(size int) (evicted int) {
 	m.mut.Lock()
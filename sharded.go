@@ -0,0 +1,204 @@
+package lru
+
+import "fmt"
+
+// ShardedLRU partitions keys across a fixed number of independent
+// MutexLRU shards, trading a single global lock for many smaller ones.
+// It's a drop-in high-concurrency alternative to MutexLRU for workloads
+// that would otherwise contend heavily on one mutex.
+type ShardedLRU[K comparable, V any] struct {
+	shards []*MutexLRU[K, V]
+	mask   uint64
+	hash   func(K) uint64
+}
+
+// NewSharded creates a new ShardedLRU of the given total size, split
+// across shards independent MutexLRU instances (each sized
+// ceil(size/shards)). shards is rounded up to the next power of two so
+// that shard selection can use a mask instead of a modulo.
+//
+// If hash is nil, a default hasher is used: fast paths for common
+// integer and string keys, falling back to FNV-1a over fmt.Sprint(key)
+// for everything else.
+func NewSharded[K comparable, V any](size, shards int, hash func(K) uint64) *ShardedLRU[K, V] {
+	if shards < 1 {
+		shards = 1
+	}
+	shards = nextPow2(shards)
+	if hash == nil {
+		hash = defaultHash[K]
+	}
+
+	s := &ShardedLRU[K, V]{
+		shards: make([]*MutexLRU[K, V], shards),
+		mask:   uint64(shards - 1),
+		hash:   hash,
+	}
+	shardSize := shardSize(size, shards)
+	for i := range s.shards {
+		s.shards[i] = NewWithMutex[K, V](shardSize)
+	}
+	return s
+}
+
+func nextPow2(n int) int {
+	p := 1
+	for p < n {
+		p <<= 1
+	}
+	return p
+}
+
+func shardSize(size, shards int) int {
+	if size <= 0 {
+		return 0
+	}
+	n := (size + shards - 1) / shards
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
+
+func defaultHash[K comparable](key K) uint64 {
+	switch k := any(key).(type) {
+	case string:
+		return fnv1a(k)
+	case int:
+		return uint64(k)
+	case int8:
+		return uint64(k)
+	case int16:
+		return uint64(k)
+	case int32:
+		return uint64(k)
+	case int64:
+		return uint64(k)
+	case uint:
+		return uint64(k)
+	case uint8:
+		return uint64(k)
+	case uint16:
+		return uint64(k)
+	case uint32:
+		return uint64(k)
+	case uint64:
+		return k
+	default:
+		return fnv1a(fmt.Sprint(key))
+	}
+}
+
+func fnv1a(s string) uint64 {
+	const (
+		offset64 = 14695981039346656037
+		prime64  = 1099511628211
+	)
+	h := uint64(offset64)
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= prime64
+	}
+	return h
+}
+
+func (s *ShardedLRU[K, V]) shardFor(key K) *MutexLRU[K, V] {
+	return s.shards[s.hash(key)&s.mask]
+}
+
+// OnEvicted optionally specifies a callback function to be
+// executed when an entry is purged from any shard of the cache.
+func (s *ShardedLRU[K, V]) OnEvicted(cb func(K, V)) {
+	for _, shard := range s.shards {
+		shard.OnEvicted(cb)
+	}
+}
+
+// Set sets a value to the sharded lru cache.
+func (s *ShardedLRU[K, V]) Set(key K, value V) {
+	s.shardFor(key).Set(key, value)
+}
+
+// Get looks up a key's value from the sharded lru cache.
+func (s *ShardedLRU[K, V]) Get(key K) (value V, ok bool) {
+	return s.shardFor(key).Get(key)
+}
+
+// Pick returns the key value (or undefined if not found)
+// without updating the "recently used"-ness of the key.
+func (s *ShardedLRU[K, V]) Pick(key K) (value V, ok bool) {
+	return s.shardFor(key).Pick(key)
+}
+
+// Remove removes the provided key from the cache.
+func (s *ShardedLRU[K, V]) Remove(key K) (value V, ok bool) {
+	return s.shardFor(key).Remove(key)
+}
+
+// Resize changes the sharded lru cache's total size, proportionally
+// dividing it across shards.
+func (s *ShardedLRU[K, V]) Resize(size int) (evicted int) {
+	n := shardSize(size, len(s.shards))
+	for _, shard := range s.shards {
+		evicted += shard.Resize(n)
+	}
+	return
+}
+
+// Len returns the number of items across all shards of the cache.
+func (s *ShardedLRU[K, V]) Len() int {
+	n := 0
+	for _, shard := range s.shards {
+		n += shard.Len()
+	}
+	return n
+}
+
+// Clear purges all stored items from every shard of the cache.
+func (s *ShardedLRU[K, V]) Clear() {
+	for _, shard := range s.shards {
+		shard.Clear()
+	}
+}
+
+// All returns an iterator over key-value pairs in the cache, walking
+// shards sequentially and each shard from newest to oldest. Ordering is
+// only guaranteed within a shard, not across the whole cache.
+func (s *ShardedLRU[K, V]) All() func(yield func(K, V) bool) {
+	return func(yield func(K, V) bool) {
+		for _, shard := range s.shards {
+			done := false
+			shard.All()(func(k K, v V) bool {
+				if !yield(k, v) {
+					done = true
+					return false
+				}
+				return true
+			})
+			if done {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over key-value pairs in the cache,
+// walking shards sequentially and each shard from oldest to newest.
+// Ordering is only guaranteed within a shard, not across the whole cache.
+func (s *ShardedLRU[K, V]) Backward() func(yield func(K, V) bool) {
+	return func(yield func(K, V) bool) {
+		for _, shard := range s.shards {
+			done := false
+			shard.Backward()(func(k K, v V) bool {
+				if !yield(k, v) {
+					done = true
+					return false
+				}
+				return true
+			})
+			if done {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package lru
+
+import "testing"
+
+func TestShardedSetGet(t *testing.T) {
+	s := NewSharded[string, int](16, 4, nil)
+	s.Set("a", 1)
+	s.Set("b", 2)
+
+	if a, ok := s.Get("a"); !ok || a != 1 {
+		t.Fatalf("get a: %v %v", a, ok)
+	}
+	if c, ok := s.Get("c"); ok || c != 0 {
+		t.Fatalf("get c: %v %v", c, ok)
+	}
+}
+
+func TestShardedRoundsShardsUpToPowerOfTwo(t *testing.T) {
+	s := NewSharded[string, int](16, 3, nil)
+	if len(s.shards) != 4 {
+		t.Fatalf("shards: %v", len(s.shards))
+	}
+}
+
+func TestShardedRemove(t *testing.T) {
+	s := NewSharded[string, int](16, 4, nil)
+	s.Set("a", 1)
+
+	if a, ok := s.Remove("a"); !ok || a != 1 {
+		t.Fatalf("remove a: %v %v", a, ok)
+	}
+	if s.Len() != 0 {
+		t.Fatalf("len after remove: %v", s.Len())
+	}
+}
+
+func TestShardedLenAndClear(t *testing.T) {
+	s := NewSharded[int, int](16, 4, nil)
+	for i := 0; i < 10; i++ {
+		s.Set(i, i)
+	}
+	if s.Len() != 10 {
+		t.Fatalf("len: %v", s.Len())
+	}
+
+	s.Clear()
+	if s.Len() != 0 {
+		t.Fatalf("len after clear: %v", s.Len())
+	}
+}
+
+func TestShardedCustomHash(t *testing.T) {
+	calls := 0
+	s := NewSharded[int, int](16, 4, func(k int) uint64 {
+		calls++
+		return uint64(k)
+	})
+	s.Set(1, 1)
+	s.Get(1)
+
+	if calls == 0 {
+		t.Fatalf("custom hash was never called")
+	}
+}
+
+func TestShardedResize(t *testing.T) {
+	s := NewSharded[int, int](16, 4, nil)
+	for i := 0; i < 16; i++ {
+		s.Set(i, i)
+	}
+
+	s.Resize(4)
+	if s.Len() > 4 {
+		t.Fatalf("len after resize: %v", s.Len())
+	}
+}
+
+func TestShardedAll(t *testing.T) {
+	s := NewSharded[int, int](16, 4, nil)
+	for i := 0; i < 10; i++ {
+		s.Set(i, i)
+	}
+
+	seen := make(map[int]bool)
+	s.All()(func(k, v int) bool {
+		seen[k] = true
+		return true
+	})
+	if len(seen) != 10 {
+		t.Fatalf("seen: %v", len(seen))
+	}
+}
@@ -0,0 +1,191 @@
+package lru
+
+// defaultRecentRatio and defaultGhostRatio follow the sizing used in the
+// original 2Q paper: the recent queue (A1in) gets a quarter of the total
+// size and the ghost queue (A1out) half of it.
+const (
+	defaultRecentRatio = 0.25
+	defaultGhostRatio  = 0.5
+)
+
+// TwoQueue is a fixed size 2Q cache. It keeps one-time sequential scans
+// from flushing out frequently used entries by routing first-time keys
+// through a small recent queue before they earn a place in the larger
+// frequent queue, using a ghost queue of evicted recent keys to decide
+// when that promotion should happen.
+type TwoQueue[K comparable, V any] struct {
+	recent      *LRU[K, V]        // A1in: recently seen, resident
+	frequent    *LRU[K, V]        // Am: frequently seen, resident
+	recentEvict *LRU[K, struct{}] // A1out: recently evicted, ghost keys only
+
+	evicted func(K, V)
+}
+
+// New2Q creates a new 2Q cache of the given size, using the default
+// recent/ghost ratios from the original 2Q paper.
+func New2Q[K comparable, V any](size int) *TwoQueue[K, V] {
+	return New2QParams[K, V](size, defaultRecentRatio, defaultGhostRatio)
+}
+
+// New2QParams creates a new 2Q cache of the given size with a custom
+// recent queue ratio and ghost queue ratio, each expected in (0, 1).
+// Out-of-range ratios fall back to the package defaults.
+func New2QParams[K comparable, V any](size int, recentRatio, ghostRatio float64) *TwoQueue[K, V] {
+	if recentRatio <= 0 || recentRatio >= 1 {
+		recentRatio = defaultRecentRatio
+	}
+	if ghostRatio <= 0 || ghostRatio >= 1 {
+		ghostRatio = defaultGhostRatio
+	}
+
+	recentSize := int(float64(size) * recentRatio)
+	if size > 0 && recentSize < 1 {
+		recentSize = 1
+	}
+	ghostSize := int(float64(size) * ghostRatio)
+	if size > 0 && ghostSize < 1 {
+		ghostSize = 1
+	}
+
+	tq := &TwoQueue[K, V]{
+		recent:      New[K, V](recentSize),
+		frequent:    New[K, V](size),
+		recentEvict: New[K, struct{}](ghostSize),
+	}
+	tq.recent.OnEvicted(func(k K, v V) {
+		tq.recentEvict.Set(k, struct{}{})
+		if tq.evicted != nil {
+			tq.evicted(k, v)
+		}
+	})
+	tq.frequent.OnEvicted(func(k K, v V) {
+		if tq.evicted != nil {
+			tq.evicted(k, v)
+		}
+	})
+	return tq
+}
+
+// OnEvicted optionally specifies a callback function to be
+// executed when an entry's value is evicted from the 2Q cache, whether
+// from the frequent queue or from the recent queue (whose key then stays
+// behind as a ghost entry). Ghost entries dropping out of the ghost
+// queue do not fire this callback, since they hold no value.
+func (tq *TwoQueue[K, V]) OnEvicted(cb func(K, V)) {
+	tq.evicted = cb
+}
+
+// Get looks up a key's value from the 2Q cache, promoting a recent-queue
+// hit into the frequent queue.
+func (tq *TwoQueue[K, V]) Get(key K) (value V, ok bool) {
+	if value, ok = tq.frequent.Get(key); ok {
+		return value, true
+	}
+	if value, ok = tq.recent.Pick(key); ok {
+		tq.recent.removeQuiet(key)
+		tq.frequent.Set(key, value)
+		return value, true
+	}
+	return
+}
+
+// Pick returns the key value (or undefined if not found)
+// without updating the "recently used"-ness of the key.
+func (tq *TwoQueue[K, V]) Pick(key K) (value V, ok bool) {
+	if value, ok = tq.frequent.Pick(key); ok {
+		return value, true
+	}
+	return tq.recent.Pick(key)
+}
+
+// Set sets a value to the 2Q cache. An existing frequent key is updated
+// in place, an existing recent key is promoted with the new value, a
+// ghost key skips straight into the frequent queue, and anything else
+// enters the recent queue.
+func (tq *TwoQueue[K, V]) Set(key K, value V) {
+	if _, ok := tq.frequent.Pick(key); ok {
+		tq.frequent.Set(key, value)
+		return
+	}
+	if _, ok := tq.recent.Pick(key); ok {
+		tq.recent.removeQuiet(key)
+		tq.frequent.Set(key, value)
+		return
+	}
+	if _, ok := tq.recentEvict.Pick(key); ok {
+		tq.recentEvict.Remove(key)
+		tq.frequent.Set(key, value)
+		return
+	}
+	tq.recent.Set(key, value)
+}
+
+// Remove removes the provided key from the cache. An explicit removal
+// from the recent queue does not plant a ghost entry: that signal is
+// reserved for keys actually pushed out by capacity pressure.
+func (tq *TwoQueue[K, V]) Remove(key K) (value V, ok bool) {
+	if value, ok = tq.frequent.Remove(key); ok {
+		return value, true
+	}
+	if value, ok = tq.recent.removeQuiet(key); ok {
+		return value, true
+	}
+	tq.recentEvict.Remove(key)
+	return
+}
+
+// Len returns the number of resident items in the 2Q cache.
+func (tq *TwoQueue[K, V]) Len() int {
+	return tq.frequent.Len() + tq.recent.Len()
+}
+
+// Clear purges all stored items and ghost entries from the 2Q cache.
+func (tq *TwoQueue[K, V]) Clear() {
+	tq.frequent.Clear()
+	tq.recent.Clear()
+	tq.recentEvict.Clear()
+}
+
+// All returns an iterator over key-value pairs in the 2Q cache,
+// traversing the frequent queue before the recent queue, each from
+// newest to oldest.
+func (tq *TwoQueue[K, V]) All() func(yield func(K, V) bool) {
+	return func(yield func(K, V) bool) {
+		done := false
+		tq.frequent.All()(func(k K, v V) bool {
+			if !yield(k, v) {
+				done = true
+				return false
+			}
+			return true
+		})
+		if done {
+			return
+		}
+		tq.recent.All()(func(k K, v V) bool {
+			return yield(k, v)
+		})
+	}
+}
+
+// Backward returns an iterator over key-value pairs in the 2Q cache,
+// traversing the recent queue before the frequent queue, each from
+// oldest to newest.
+func (tq *TwoQueue[K, V]) Backward() func(yield func(K, V) bool) {
+	return func(yield func(K, V) bool) {
+		done := false
+		tq.recent.Backward()(func(k K, v V) bool {
+			if !yield(k, v) {
+				done = true
+				return false
+			}
+			return true
+		})
+		if done {
+			return
+		}
+		tq.frequent.Backward()(func(k K, v V) bool {
+			return yield(k, v)
+		})
+	}
+}
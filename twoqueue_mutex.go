@@ -0,0 +1,111 @@
+package lru
+
+import "sync"
+
+// MutexTwoQueue is a thread-safe 2Q cache.
+type MutexTwoQueue[K comparable, V any] struct {
+	mut sync.RWMutex
+	tq  *TwoQueue[K, V]
+}
+
+// New2QWithMutex creates a new thread-safe 2Q cache of the given size,
+// using the default recent/ghost ratios from the original 2Q paper.
+func New2QWithMutex[K comparable, V any](size int) *MutexTwoQueue[K, V] {
+	return &MutexTwoQueue[K, V]{
+		tq: New2Q[K, V](size),
+	}
+}
+
+// New2QParamsWithMutex creates a new thread-safe 2Q cache of the given
+// size with a custom recent queue ratio and ghost queue ratio, each
+// expected in (0, 1).
+func New2QParamsWithMutex[K comparable, V any](size int, recentRatio, ghostRatio float64) *MutexTwoQueue[K, V] {
+	return &MutexTwoQueue[K, V]{
+		tq: New2QParams[K, V](size, recentRatio, ghostRatio),
+	}
+}
+
+// OnEvicted optionally specifies a callback function to be
+// executed when an entry's value is evicted from the 2Q cache.
+func (m *MutexTwoQueue[K, V]) OnEvicted(cb func(K, V)) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	m.tq.OnEvicted(func(k K, v V) {
+		m.mut.Unlock()
+		defer m.mut.Lock()
+		cb(k, v)
+	})
+}
+
+// Get looks up a key's value from the 2Q cache.
+func (m *MutexTwoQueue[K, V]) Get(key K) (value V, ok bool) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	return m.tq.Get(key)
+}
+
+// Pick returns the key value (or undefined if not found)
+// without updating the "recently used"-ness of the key.
+func (m *MutexTwoQueue[K, V]) Pick(key K) (value V, ok bool) {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+	return m.tq.Pick(key)
+}
+
+// Set sets a value to the 2Q cache.
+func (m *MutexTwoQueue[K, V]) Set(key K, value V) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	m.tq.Set(key, value)
+}
+
+// Remove removes the provided key from the 2Q cache.
+func (m *MutexTwoQueue[K, V]) Remove(key K) (value V, ok bool) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	return m.tq.Remove(key)
+}
+
+// Len returns the number of resident items in the 2Q cache.
+func (m *MutexTwoQueue[K, V]) Len() int {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+	return m.tq.Len()
+}
+
+// Clear purges all stored items and ghost entries from the 2Q cache.
+func (m *MutexTwoQueue[K, V]) Clear() {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	m.tq.Clear()
+}
+
+// All returns an iterator over key-value pairs in the 2Q cache,
+// traversing the frequent queue before the recent queue, each from
+// newest to oldest.
+func (m *MutexTwoQueue[K, V]) All() func(yield func(K, V) bool) {
+	return func(yield func(K, V) bool) {
+		m.mut.Lock()
+		defer m.mut.Unlock()
+		m.tq.All()(func(key K, value V) bool {
+			m.mut.Unlock()
+			defer m.mut.Lock()
+			return yield(key, value)
+		})
+	}
+}
+
+// Backward returns an iterator over key-value pairs in the 2Q cache,
+// traversing the recent queue before the frequent queue, each from
+// oldest to newest.
+func (m *MutexTwoQueue[K, V]) Backward() func(yield func(K, V) bool) {
+	return func(yield func(K, V) bool) {
+		m.mut.Lock()
+		defer m.mut.Unlock()
+		m.tq.Backward()(func(key K, value V) bool {
+			m.mut.Unlock()
+			defer m.mut.Lock()
+			return yield(key, value)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package lru
+
+import "testing"
+
+func TestTwoQueueSetGet(t *testing.T) {
+	tq := New2Q[string, int](8)
+	tq.Set("a", 1)
+
+	if a, ok := tq.Get("a"); !ok || a != 1 {
+		t.Fatalf("get a: %v %v", a, ok)
+	}
+	if c, ok := tq.Get("c"); ok || c != 0 {
+		t.Fatalf("get c: %v %v", c, ok)
+	}
+}
+
+func TestTwoQueuePromotesOnSecondHit(t *testing.T) {
+	tq := New2Q[string, int](8)
+	tq.Set("a", 1)
+
+	if _, ok := tq.frequent.Pick("a"); ok {
+		t.Fatalf("a should not start frequent")
+	}
+
+	tq.Get("a")
+
+	if _, ok := tq.frequent.Pick("a"); !ok {
+		t.Fatalf("a should be promoted to frequent after a hit")
+	}
+	if _, ok := tq.recent.Pick("a"); ok {
+		t.Fatalf("a should no longer be in the recent queue")
+	}
+}
+
+func TestTwoQueueGhostPromotesDirectlyToFrequent(t *testing.T) {
+	tq := New2QParams[string, int](4, 0.25, 0.5)
+	tq.Set("a", 1)
+
+	// Evict "a" out of the (size-1) recent queue.
+	tq.Set("b", 2)
+
+	if _, ok := tq.recentEvict.Pick("a"); !ok {
+		t.Fatalf("a should have become a ghost entry")
+	}
+
+	tq.Set("a", 11)
+
+	if _, ok := tq.frequent.Pick("a"); !ok {
+		t.Fatalf("a should enter the frequent queue directly from the ghost list")
+	}
+}
+
+func TestTwoQueuePromotionDoesNotPlantGhost(t *testing.T) {
+	tq := New2Q[string, int](8)
+	tq.Set("a", 1)
+	tq.Get("a") // pure promotion, not a capacity eviction
+
+	if _, ok := tq.recentEvict.Pick("a"); ok {
+		t.Fatalf("promoting a recent hit should not plant a ghost entry")
+	}
+}
+
+func TestTwoQueueExplicitRemoveDoesNotPlantGhost(t *testing.T) {
+	tq := New2Q[string, int](8)
+	tq.Set("a", 1)
+	tq.Remove("a")
+
+	if _, ok := tq.recentEvict.Pick("a"); ok {
+		t.Fatalf("explicitly removing a recent key should not plant a ghost entry")
+	}
+
+	// A later Set for the same key should re-enter the recent queue,
+	// not skip straight to frequent as if it were a ghost hit.
+	tq.Set("a", 2)
+	if _, ok := tq.frequent.Pick("a"); ok {
+		t.Fatalf("a should not have entered frequent straight from a forgotten ghost")
+	}
+	if _, ok := tq.recent.Pick("a"); !ok {
+		t.Fatalf("a should have re-entered the recent queue")
+	}
+}
+
+func TestTwoQueueRemove(t *testing.T) {
+	tq := New2Q[string, int](8)
+	tq.Set("a", 1)
+	tq.Get("a")
+
+	if a, ok := tq.Remove("a"); !ok || a != 1 {
+		t.Fatalf("remove a: %v %v", a, ok)
+	}
+	if tq.Len() != 0 {
+		t.Fatalf("len after remove: %v", tq.Len())
+	}
+}
+
+func TestTwoQueueOnEvicted(t *testing.T) {
+	tq := New2QParams[string, int](2, 0.5, 0.5)
+	var evictedKey string
+	tq.OnEvicted(func(k string, _ int) {
+		evictedKey = k
+	})
+
+	tq.Set("a", 1)
+	tq.Get("a") // promote to frequent
+	tq.Set("b", 2)
+	tq.Get("b") // promote to frequent, filling the frequent queue
+	tq.Set("c", 3)
+	tq.Get("c")
+
+	if evictedKey == "" {
+		t.Fatalf("expected an eviction from the frequent queue")
+	}
+}
+
+func TestTwoQueueClear(t *testing.T) {
+	tq := New2Q[string, int](8)
+	tq.Set("a", 1)
+	tq.Get("a")
+	tq.Set("b", 2)
+
+	tq.Clear()
+
+	if tq.Len() != 0 {
+		t.Fatalf("len after clear: %v", tq.Len())
+	}
+}